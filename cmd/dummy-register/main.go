@@ -0,0 +1,91 @@
+// Command dummy-register runs the patient registration HTTP API.
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/andreasaldito/dummy-register/internal/auth"
+	"github.com/andreasaldito/dummy-register/internal/config"
+	"github.com/andreasaldito/dummy-register/internal/patient"
+	"github.com/andreasaldito/dummy-register/internal/server"
+)
+
+func main() {
+	cfgManager, configPath := loadConfig()
+	if configPath != "" {
+		watcher, err := config.Watch(configPath, cfgManager)
+		if err != nil {
+			log.Printf("config: watch %s failed: %v", configPath, err)
+		} else {
+			defer watcher.Close()
+		}
+	}
+
+	cfg := cfgManager.Snapshot()
+	if cfg.PasswordHashCost > 0 {
+		if err := auth.SetCost(cfg.PasswordHashCost); err != nil {
+			log.Fatalf("config: %v", err)
+		}
+	}
+
+	store, err := newStore(context.Background(), cfg)
+	if err != nil {
+		log.Fatalf("init store: %v", err)
+	}
+
+	srv := server.NewServer(store).WithConfig(cfgManager)
+	if user, pass := os.Getenv("ADMIN_USER"), os.Getenv("ADMIN_PASSWORD"); user != "" && pass != "" {
+		srv = srv.WithDiagnostics(user, pass)
+	} else {
+		log.Print("ADMIN_USER/ADMIN_PASSWORD not set, /api/v1/diagnostics is disabled")
+	}
+
+	addr := cfg.ListenAddr
+	if addr == "" {
+		addr = envOr("LISTEN_ADDR", ":8080")
+	}
+
+	log.Printf("listening on %s", addr)
+	if err := http.ListenAndServe(addr, srv.Routes()); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// loadConfig reads config.yaml from the path in CONFIG_PATH (default
+// "config.yaml"), falling back to a config.Manager with zero-value
+// settings (and thus env-var-driven defaults) if the file is absent, so
+// the server still starts without one. It returns the path actually
+// loaded, or "" if none was, so the caller knows whether to watch it.
+func loadConfig() (*config.Manager, string) {
+	path := envOr("CONFIG_PATH", "config.yaml")
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		log.Printf("config: %s not loaded (%v), using defaults", path, err)
+		return config.NewManager(config.Config{}), ""
+	}
+	return config.NewManager(cfg), path
+}
+
+// newStore selects a patient.Store implementation: PostgreSQL if a DSN
+// is configured (via config.yaml or DATABASE_DSN), otherwise in-memory.
+func newStore(ctx context.Context, cfg config.Config) (patient.Store, error) {
+	dsn := cfg.DatabaseDSN
+	if dsn == "" {
+		dsn = os.Getenv("DATABASE_DSN")
+	}
+	if dsn != "" {
+		return patient.NewPostgresStore(ctx, dsn)
+	}
+	return patient.NewMemStore(), nil
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// RequireAdminBasicAuth wraps next so that requests must present HTTP
+// BasicAuth credentials matching adminUser/adminPass, checked in
+// constant time.
+func RequireAdminBasicAuth(adminUser, adminPass string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !checkAdminAuth(r, adminUser, adminPass) {
+			w.Header().Set("WWW-Authenticate", `Basic realm="admin"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// checkAdminAuth validates HTTP BasicAuth credentials against the
+// configured admin user, in constant time.
+func checkAdminAuth(r *http.Request, adminUser, adminPass string) bool {
+	user, pass, ok := r.BasicAuth()
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(user), []byte(adminUser)) == 1 &&
+		subtle.ConstantTimeCompare([]byte(pass), []byte(adminPass)) == 1
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/andreasaldito/dummy-register/internal/auth"
+	"github.com/andreasaldito/dummy-register/internal/config"
+)
+
+// ConfigHandler serves GET/PATCH /api/v1/config on top of a
+// config.Handler. PATCH requires the current fingerprint in an If-Match
+// header so two concurrent edits cannot silently clobber each other.
+type ConfigHandler struct {
+	cfg config.Handler
+}
+
+// NewConfigHandler returns a ConfigHandler backed by cfg.
+func NewConfigHandler(cfg config.Handler) *ConfigHandler {
+	return &ConfigHandler{cfg: cfg}
+}
+
+func (h *ConfigHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.get(w, r)
+	case http.MethodPatch:
+		h.patch(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *ConfigHandler) get(w http.ResponseWriter, r *http.Request) {
+	data, err := h.cfg.MarshalJSON()
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("ETag", h.cfg.Fingerprint())
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+// patchRequest is a single JSON-Pointer edit: the field at Path is
+// replaced with Value.
+type patchRequest struct {
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+func (h *ConfigHandler) patch(w http.ResponseWriter, r *http.Request) {
+	fingerprint := r.Header.Get("If-Match")
+	if fingerprint == "" {
+		http.Error(w, "If-Match header is required", http.StatusPreconditionRequired)
+		return
+	}
+
+	var req patchRequest
+	if err := decodeJSON(r, &req); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	err := h.cfg.DoLockedAction(fingerprint, func(cfg *config.Config) error {
+		oldCost := cfg.PasswordHashCost
+		if err := config.ApplyJSONPath(cfg, req.Path, req.Value); err != nil {
+			return err
+		}
+
+		// password_hash_cost must also take effect on auth's live bcrypt
+		// cost, same as it does on reload from disk (see config.Watch) -
+		// otherwise this patch would report success while every password
+		// hash kept using the old cost.
+		if req.Path == "/password_hash_cost" && cfg.PasswordHashCost != oldCost {
+			if err := auth.SetCost(cfg.PasswordHashCost); err != nil {
+				cfg.PasswordHashCost = oldCost
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, config.ErrFingerprintMismatch) {
+		http.Error(w, "Config was modified concurrently; refetch and retry", http.StatusPreconditionFailed)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Invalid patch: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := h.cfg.MarshalJSON()
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("ETag", h.cfg.Fingerprint())
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
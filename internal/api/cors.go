@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/andreasaldito/dummy-register/internal/config"
+)
+
+// CORS returns middleware that sets Access-Control-Allow-Origin for
+// requests whose Origin is in cfg's current CORS allow-list, reading it
+// fresh on every request so config hot-reloads take effect immediately.
+// It also answers OPTIONS preflight requests directly, before they can
+// reach auth middleware further down the chain that would otherwise
+// reject them for lacking a bearer token.
+func CORS(cfg *config.Manager, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		allowed := origin != "" && cfg.AllowsOrigin(origin)
+		if allowed {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Vary", "Origin")
+		}
+
+		if r.Method == http.MethodOptions {
+			if allowed {
+				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE")
+				w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, If-Match")
+				w.WriteHeader(http.StatusNoContent)
+			} else {
+				http.Error(w, "Origin not allowed", http.StatusForbidden)
+			}
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/andreasaldito/dummy-register/internal/diag"
+)
+
+// diagnosticsHandler serves GET /api/v1/diagnostics, returning a fixed
+// allow-list of runtime metrics, or - given a ?cmd= query param - the
+// output of one vetted ops command via diag.RunAllowed.
+type diagnosticsHandler struct {
+	pinger diag.Pinger
+}
+
+// NewDiagnosticsHandler returns a handler for GET /api/v1/diagnostics,
+// reporting on pinger (which may be nil if the store has no database to
+// ping), gated by the given admin BasicAuth credentials.
+func NewDiagnosticsHandler(pinger diag.Pinger, adminUser, adminPass string) http.Handler {
+	return RequireAdminBasicAuth(adminUser, adminPass, &diagnosticsHandler{pinger: pinger})
+}
+
+// cmdResult is the response to a GET /api/v1/diagnostics?cmd=... request.
+type cmdResult struct {
+	Command string `json:"command"`
+	Output  string `json:"output"`
+}
+
+func (h *diagnosticsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if cmd := r.URL.Query().Get("cmd"); cmd != "" {
+		output, err := diag.RunAllowed(cmd)
+		if err != nil {
+			http.Error(w, "diagnostics: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, http.StatusOK, cmdResult{Command: cmd, Output: output})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, diag.Collect(r.Context(), h.pinger))
+}
@@ -0,0 +1,24 @@
+package api
+
+import "github.com/andreasaldito/dummy-register/internal/patient"
+
+// patientDTO is the JSON representation of a patient returned to
+// clients. It deliberately omits Password so hashes never leave the
+// server.
+type patientDTO struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func toDTO(p patient.Patient) patientDTO {
+	return patientDTO{ID: p.ID, Name: p.Name, Age: p.Age}
+}
+
+func toDTOs(list []patient.Patient) []patientDTO {
+	dtos := make([]patientDTO, len(list))
+	for i, p := range list {
+		dtos[i] = toDTO(p)
+	}
+	return dtos
+}
@@ -0,0 +1,205 @@
+// Package api exposes the HTTP handlers for the dummy-register service.
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/andreasaldito/dummy-register/internal/auth"
+	"github.com/andreasaldito/dummy-register/internal/patient"
+)
+
+var patientIDPath = regexp.MustCompile(`^/api/v1/patients/([0-9]+)$`)
+
+// PatientHandler serves the /api/v1/patients endpoints on top of a
+// patient.Store, satisfying http.Handler.
+type PatientHandler struct {
+	store patient.Store
+}
+
+// NewPatientHandler returns a PatientHandler backed by store.
+func NewPatientHandler(store patient.Store) *PatientHandler {
+	return &PatientHandler{store: store}
+}
+
+func (h *PatientHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/api/v1/patients" {
+		switch r.Method {
+		case http.MethodGet:
+			h.list(w, r)
+		case http.MethodPost:
+			h.create(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	if m := patientIDPath.FindStringSubmatch(r.URL.Path); m != nil {
+		id, _ := strconv.Atoi(m[1]) // guaranteed numeric by the regex
+
+		switch r.Method {
+		case http.MethodGet:
+			h.get(w, r, id)
+		case http.MethodPut:
+			h.update(w, r, id)
+		case http.MethodDelete:
+			h.delete(w, r, id)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	http.NotFound(w, r)
+}
+
+func (h *PatientHandler) list(w http.ResponseWriter, r *http.Request) {
+	list, err := h.store.List()
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, toDTOs(list))
+}
+
+func (h *PatientHandler) create(w http.ResponseWriter, r *http.Request) {
+	var p patient.Patient
+	if err := decodeJSON(r, &p); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	if !validatePatientName(p.Name) {
+		http.Error(w, "Invalid patient name", http.StatusBadRequest)
+		return
+	}
+
+	hashed, err := auth.HashPassword(p.Password)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	p.Password = hashed
+
+	created, err := h.store.Add(p)
+	if errors.Is(err, patient.ErrDuplicateName) {
+		http.Error(w, "Patient already exists", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toDTO(created))
+}
+
+func (h *PatientHandler) get(w http.ResponseWriter, r *http.Request, id int) {
+	p, err := h.store.Find(id)
+	if errors.Is(err, patient.ErrNotFound) {
+		http.Error(w, "Patient not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, toDTO(p))
+}
+
+func (h *PatientHandler) update(w http.ResponseWriter, r *http.Request, id int) {
+	if !requireOwnPatient(w, r, id) {
+		return
+	}
+
+	var update patient.Patient
+	if err := decodeJSON(r, &update); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	if update.Password != "" {
+		hashed, err := auth.HashPassword(update.Password)
+		if err != nil {
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		update.Password = hashed
+	}
+
+	updated, err := h.store.Update(id, update)
+	if errors.Is(err, patient.ErrNotFound) {
+		http.Error(w, "Patient not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, patient.ErrDuplicateName) {
+		http.Error(w, "Patient already exists", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, toDTO(updated))
+}
+
+func (h *PatientHandler) delete(w http.ResponseWriter, r *http.Request, id int) {
+	if !requireOwnPatient(w, r, id) {
+		return
+	}
+
+	err := h.store.Delete(id)
+	if errors.Is(err, patient.ErrNotFound) {
+		http.Error(w, "Patient not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// requireOwnPatient reports whether r's bearer token was issued for
+// id, writing a 403 response and returning false if not. A valid
+// token only proves who the caller is; it must match the patient the
+// request is trying to modify.
+func requireOwnPatient(w http.ResponseWriter, r *http.Request, id int) bool {
+	authID, ok := AuthenticatedPatientID(r)
+	if !ok || authID != id {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// validatePatientName reports whether name looks like a person's name
+// ("First Last"-style, each word capitalized).
+var patientNameRE = regexp.MustCompile(`^[A-Z][a-z]+(?:\s[A-Z][a-z]+)*$`)
+
+func validatePatientName(name string) bool {
+	return patientNameRE.MatchString(name)
+}
+
+func decodeJSON(r *http.Request, v interface{}) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	resp, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(resp)
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/andreasaldito/dummy-register/internal/auth"
+	"github.com/andreasaldito/dummy-register/internal/patient"
+)
+
+type loginRequest struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+type loginResponse struct {
+	Token string `json:"token"`
+}
+
+// LoginHandler authenticates a patient by name and password and issues
+// a bearer token on success.
+type LoginHandler struct {
+	store  patient.Store
+	tokens *auth.TokenStore
+}
+
+// NewLoginHandler returns a LoginHandler backed by store, issuing
+// tokens from tokens.
+func NewLoginHandler(store patient.Store, tokens *auth.TokenStore) *LoginHandler {
+	return &LoginHandler{store: store, tokens: tokens}
+}
+
+func (h *LoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req loginRequest
+	if err := decodeJSON(r, &req); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	p, err := h.store.FindByName(req.Name)
+	if err != nil || !h.authenticate(p, req.Password) {
+		http.Error(w, "Invalid credentials", http.StatusUnauthorized)
+		return
+	}
+
+	token, err := h.tokens.Issue(p.ID)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, loginResponse{Token: token})
+}
+
+// authenticate verifies password against p's stored hash, transparently
+// upgrading a legacy MD5 hash to bcrypt on successful verification.
+func (h *LoginHandler) authenticate(p patient.Patient, password string) bool {
+	if auth.IsLegacyMD5Hash(p.Password) {
+		if !auth.VerifyLegacyMD5(p.Password, password) {
+			return false
+		}
+		if upgraded, err := auth.HashPassword(password); err == nil {
+			p.Password = upgraded
+			h.store.Update(p.ID, p) // best-effort; login still succeeds if this fails
+		}
+		return true
+	}
+
+	return auth.ComparePassword(p.Password, password) == nil
+}
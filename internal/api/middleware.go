@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/andreasaldito/dummy-register/internal/auth"
+)
+
+type contextKey int
+
+const authenticatedPatientIDKey contextKey = iota
+
+// RequireBearerTokenForMutations wraps next so that GET and POST
+// requests pass through unauthenticated - POST is how a patient
+// registers in the first place, so it can't require a token already
+// issued to that same patient - while PUT and DELETE require a valid
+// bearer token issued by tokens. The patient ID the token was issued
+// for is attached to the request context; handlers that act on a
+// specific patient ID must check it against AuthenticatedPatientID
+// themselves, since a valid token only proves who the caller is, not
+// that they're allowed to touch the record named in the path.
+func RequireBearerTokenForMutations(tokens *auth.TokenStore, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet || r.Method == http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		const prefix = "Bearer "
+		header := r.Header.Get("Authorization")
+		if !strings.HasPrefix(header, prefix) {
+			http.Error(w, "Missing bearer token", http.StatusUnauthorized)
+			return
+		}
+
+		patientID, ok := tokens.Lookup(strings.TrimPrefix(header, prefix))
+		if !ok {
+			http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), authenticatedPatientIDKey, patientID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// AuthenticatedPatientID returns the patient ID that r's bearer token
+// was issued for, as attached by RequireBearerTokenForMutations. It
+// returns false if r carries no such token, e.g. for GET/POST requests.
+func AuthenticatedPatientID(r *http.Request) (int, bool) {
+	id, ok := r.Context().Value(authenticatedPatientIDKey).(int)
+	return id, ok
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"net/http"
+
+	"golang.org/x/time/rate"
+
+	"github.com/andreasaldito/dummy-register/internal/config"
+)
+
+// RateLimit returns middleware that enforces cfg's currently configured
+// requests-per-second budget across all callers, re-reading the limit
+// from cfg on every request so config hot-reloads take effect
+// immediately. A limit of 0 or less disables rate limiting.
+func RateLimit(cfg *config.Manager, next http.Handler) http.Handler {
+	limiter := rate.NewLimiter(rate.Inf, 1)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rps := cfg.RateLimitRPS()
+		if rps <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		limiter.SetLimit(rate.Limit(rps))
+		limiter.SetBurst(int(rps) + 1)
+		if !limiter.Allow() {
+			http.Error(w, "Rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,22 @@
+package auth
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"regexp"
+)
+
+var md5HashRE = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// IsLegacyMD5Hash reports whether hash has the shape of an MD5 digest
+// left over from before passwords were hashed with bcrypt.
+func IsLegacyMD5Hash(hash string) bool {
+	return md5HashRE.MatchString(hash)
+}
+
+// VerifyLegacyMD5 reports whether plain hashes to the legacy MD5 digest
+// hash, so a stored pre-bcrypt password can still be verified once.
+func VerifyLegacyMD5(hash, plain string) bool {
+	sum := md5.Sum([]byte(plain))
+	return hex.EncodeToString(sum[:]) == hash
+}
@@ -0,0 +1,39 @@
+// Package auth provides password hashing and bearer-token session
+// management for the patient API.
+package auth
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var cost = int32(bcrypt.DefaultCost)
+
+// SetCost updates the bcrypt cost factor used by future HashPassword
+// calls, typically from the operator-configured password_hash_cost. n
+// outside bcrypt's valid range (bcrypt.MinCost..bcrypt.MaxCost) is
+// rejected and leaves the existing cost in place, since every future
+// HashPassword call would otherwise fail.
+func SetCost(n int) error {
+	if n < bcrypt.MinCost || n > bcrypt.MaxCost {
+		return fmt.Errorf("auth: cost %d outside valid range %d-%d", n, bcrypt.MinCost, bcrypt.MaxCost)
+	}
+	atomic.StoreInt32(&cost, int32(n))
+	return nil
+}
+
+// HashPassword hashes plain with bcrypt for storage.
+func HashPassword(plain string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(plain), int(atomic.LoadInt32(&cost)))
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// ComparePassword reports whether plain matches hash, in constant time.
+func ComparePassword(hash, plain string) error {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(plain))
+}
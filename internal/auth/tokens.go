@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+)
+
+// TokenStore issues and validates opaque bearer tokens, mapping each
+// token to the patient ID it was issued for. Tokens live only for the
+// lifetime of the process.
+type TokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]int
+}
+
+// NewTokenStore returns an empty TokenStore.
+func NewTokenStore() *TokenStore {
+	return &TokenStore{tokens: make(map[string]int)}
+}
+
+// Issue creates and stores a new token for patientID.
+func (s *TokenStore) Issue(patientID int) (string, error) {
+	token, err := generateToken()
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	s.tokens[token] = patientID
+	s.mu.Unlock()
+	return token, nil
+}
+
+// Lookup returns the patient ID associated with token, if any.
+func (s *TokenStore) Lookup(token string) (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, ok := s.tokens[token]
+	return id, ok
+}
+
+// Revoke invalidates token, if it exists.
+func (s *TokenStore) Revoke(token string) {
+	s.mu.Lock()
+	delete(s.tokens, token)
+	s.mu.Unlock()
+}
+
+func generateToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,144 @@
+// Package config provides hot-reloadable server configuration, loaded
+// from YAML and editable piecemeal over HTTP via JSON Pointer paths,
+// with fingerprint-guarded optimistic concurrency for concurrent edits.
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the server settings operators can tune without a
+// restart.
+type Config struct {
+	ListenAddr       string   `json:"listen_addr" yaml:"listen_addr"`
+	DatabaseDSN      string   `json:"database_dsn" yaml:"database_dsn"`
+	PasswordHashCost int      `json:"password_hash_cost" yaml:"password_hash_cost"`
+	CORSOrigins      []string `json:"cors_origins" yaml:"cors_origins"`
+	RateLimitRPS     float64  `json:"rate_limit_rps" yaml:"rate_limit_rps"`
+}
+
+// ErrFingerprintMismatch is returned by DoLockedAction when fingerprint
+// no longer matches the current config, meaning it was changed by
+// another request in the meantime.
+var ErrFingerprintMismatch = errors.New("config: fingerprint does not match current config")
+
+// Handler is the interface operators interact with to read and update
+// server config, whether from a file or over HTTP.
+type Handler interface {
+	MarshalJSON() ([]byte, error)
+	UnmarshalYAML(data []byte) error
+	MarshalJSONPath(path string) ([]byte, error)
+	UnmarshalJSONPath(path string, data []byte) error
+	Fingerprint() string
+	DoLockedAction(fingerprint string, cb func(cfg *Config) error) error
+}
+
+// Manager is the concrete, concurrency-safe Handler implementation
+// used by the server.
+type Manager struct {
+	mu  sync.RWMutex
+	cfg Config
+}
+
+// NewManager returns a Manager holding an initial cfg.
+func NewManager(cfg Config) *Manager {
+	return &Manager{cfg: cfg}
+}
+
+func (m *Manager) MarshalJSON() ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return json.Marshal(m.cfg)
+}
+
+func (m *Manager) UnmarshalYAML(data []byte) error {
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.cfg = cfg
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *Manager) MarshalJSONPath(path string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return readJSONPath(m.cfg, path)
+}
+
+func (m *Manager) UnmarshalJSONPath(path string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return ApplyJSONPath(&m.cfg, path, data)
+}
+
+// Fingerprint returns a digest of the current config, used as an
+// optimistic-concurrency token by PATCH requests.
+func (m *Manager) Fingerprint() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return fingerprintOf(m.cfg)
+}
+
+// DoLockedAction runs cb with exclusive access to the live config, but
+// only if fingerprint matches the config's current fingerprint;
+// otherwise it returns ErrFingerprintMismatch without calling cb. cb
+// may mutate *cfg directly, e.g. via ApplyJSONPath.
+func (m *Manager) DoLockedAction(fingerprint string, cb func(cfg *Config) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if fingerprint != fingerprintOf(m.cfg) {
+		return ErrFingerprintMismatch
+	}
+	return cb(&m.cfg)
+}
+
+// Replace swaps in cfg wholesale, used when a config file is reloaded
+// from disk.
+func (m *Manager) Replace(cfg Config) {
+	m.mu.Lock()
+	m.cfg = cfg
+	m.mu.Unlock()
+}
+
+// Snapshot returns a copy of the current config.
+func (m *Manager) Snapshot() Config {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.cfg
+}
+
+// AllowsOrigin reports whether origin is in the current CORS allow-list.
+func (m *Manager) AllowsOrigin(origin string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, o := range m.cfg.CORSOrigins {
+		if o == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// RateLimitRPS returns the currently configured request rate limit.
+func (m *Manager) RateLimitRPS() float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.cfg.RateLimitRPS
+}
+
+func fingerprintOf(cfg Config) string {
+	data, _ := json.Marshal(cfg)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// readJSONPath marshals cfg to a generic JSON document and resolves an
+// RFC 6901 JSON Pointer against it.
+func readJSONPath(cfg Config, pointer string) ([]byte, error) {
+	doc, err := toJSONDoc(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := pointerGet(doc, pointer)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(val)
+}
+
+// ApplyJSONPath decodes data as JSON and writes it into cfg at the
+// location identified by the RFC 6901 JSON Pointer pointer, leaving the
+// rest of cfg untouched.
+func ApplyJSONPath(cfg *Config, pointer string, data []byte) error {
+	var val interface{}
+	if err := json.Unmarshal(data, &val); err != nil {
+		return err
+	}
+
+	doc, err := toJSONDoc(*cfg)
+	if err != nil {
+		return err
+	}
+	if err := pointerSet(doc, pointer, val); err != nil {
+		return err
+	}
+	return fromJSONDoc(doc, cfg)
+}
+
+func toJSONDoc(cfg Config) (interface{}, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func fromJSONDoc(doc interface{}, cfg *Config) error {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(cfg)
+}
+
+func pointerGet(doc interface{}, pointer string) (interface{}, error) {
+	tokens, err := splitPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	cur := doc
+	for _, tok := range tokens {
+		next, err := descend(cur, tok)
+		if err != nil {
+			return nil, err
+		}
+		cur = next
+	}
+	return cur, nil
+}
+
+func pointerSet(doc interface{}, pointer string, val interface{}) error {
+	tokens, err := splitPointer(pointer)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		return fmt.Errorf("config: cannot replace the document root")
+	}
+
+	cur := doc
+	for _, tok := range tokens[:len(tokens)-1] {
+		next, err := descend(cur, tok)
+		if err != nil {
+			return err
+		}
+		cur = next
+	}
+
+	last := tokens[len(tokens)-1]
+	switch v := cur.(type) {
+	case map[string]interface{}:
+		v[last] = val
+	case []interface{}:
+		idx, err := arrayIndex(last, len(v))
+		if err != nil {
+			return err
+		}
+		v[idx] = val
+	default:
+		return fmt.Errorf("config: cannot set a field on %T", cur)
+	}
+	return nil
+}
+
+func descend(cur interface{}, tok string) (interface{}, error) {
+	switch v := cur.(type) {
+	case map[string]interface{}:
+		next, ok := v[tok]
+		if !ok {
+			return nil, fmt.Errorf("config: path segment %q not found", tok)
+		}
+		return next, nil
+	case []interface{}:
+		idx, err := arrayIndex(tok, len(v))
+		if err != nil {
+			return nil, err
+		}
+		return v[idx], nil
+	default:
+		return nil, fmt.Errorf("config: cannot descend into %T", cur)
+	}
+}
+
+func arrayIndex(tok string, length int) (int, error) {
+	idx, err := strconv.Atoi(tok)
+	if err != nil || idx < 0 || idx >= length {
+		return 0, fmt.Errorf("config: invalid array index %q", tok)
+	}
+	return idx, nil
+}
+
+// splitPointer splits an RFC 6901 JSON Pointer into its unescaped
+// reference tokens. The empty string denotes the whole document and
+// splits to no tokens.
+func splitPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("config: JSON Pointer must start with '/', got %q", pointer)
+	}
+
+	raw := strings.Split(pointer[1:], "/")
+	tokens := make([]string, len(raw))
+	for i, t := range raw {
+		t = strings.ReplaceAll(t, "~1", "/")
+		t = strings.ReplaceAll(t, "~0", "~")
+		tokens[i] = t
+	}
+	return tokens, nil
+}
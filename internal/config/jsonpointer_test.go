@@ -0,0 +1,43 @@
+package config
+
+import "testing"
+
+func TestApplyJSONPath_UpdatesField(t *testing.T) {
+	cfg := Config{ListenAddr: ":8080"}
+
+	if err := ApplyJSONPath(&cfg, "/listen_addr", []byte(`":9090"`)); err != nil {
+		t.Fatalf("ApplyJSONPath: %v", err)
+	}
+	if cfg.ListenAddr != ":9090" {
+		t.Fatalf("got ListenAddr %q, want %q", cfg.ListenAddr, ":9090")
+	}
+}
+
+func TestApplyJSONPath_UnknownFieldIsRejected(t *testing.T) {
+	cfg := Config{ListenAddr: ":8080"}
+
+	err := ApplyJSONPath(&cfg, "/nonexistent_field", []byte(`3`))
+	if err == nil {
+		t.Fatal("expected an error for an unknown config field, got nil")
+	}
+	if cfg.ListenAddr != ":8080" {
+		t.Fatalf("config was mutated despite the rejected patch: %+v", cfg)
+	}
+}
+
+func TestManager_DoLockedAction_RejectsStaleFingerprint(t *testing.T) {
+	m := NewManager(Config{ListenAddr: ":8080"})
+	stale := m.Fingerprint()
+	m.Replace(Config{ListenAddr: ":9090"})
+
+	err := m.DoLockedAction(stale, func(cfg *Config) error {
+		cfg.ListenAddr = ":7070"
+		return nil
+	})
+	if err != ErrFingerprintMismatch {
+		t.Fatalf("got error %v, want ErrFingerprintMismatch", err)
+	}
+	if m.Snapshot().ListenAddr != ":9090" {
+		t.Fatalf("config was mutated despite the stale fingerprint: %+v", m.Snapshot())
+	}
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"log"
+
+	"github.com/andreasaldito/dummy-register/internal/auth"
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watch reloads the YAML config file at path into m whenever it
+// changes on disk, also applying any new password_hash_cost to
+// auth.SetCost so it takes effect without a restart, same as
+// CORSOrigins and RateLimitRPS already do by being read live off m.
+// Reload errors are logged rather than returned, so a bad edit on disk
+// cannot crash the server; the previous config stays in effect. The
+// returned watcher must be closed by the caller when done.
+func Watch(path string, m *Manager) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(path); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	go func() {
+		for event := range watcher.Events {
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+
+			cfg, err := Load(path)
+			if err != nil {
+				log.Printf("config: reload %s failed: %v", path, err)
+				continue
+			}
+			if cfg.PasswordHashCost > 0 {
+				if err := auth.SetCost(cfg.PasswordHashCost); err != nil {
+					log.Printf("config: reload %s rejected: %v", path, err)
+					continue
+				}
+			}
+			m.Replace(cfg)
+			log.Printf("config: reloaded %s", path)
+		}
+	}()
+
+	return watcher, nil
+}
@@ -0,0 +1,42 @@
+// Package diag collects runtime diagnostics for the ops API and
+// provides a sandboxed way to run vetted shell-outs, replacing the
+// previous arbitrary command-execution endpoint.
+package diag
+
+import (
+	"context"
+	"runtime"
+	"time"
+)
+
+// Info is the fixed allow-list of runtime metrics exposed by the
+// diagnostics endpoint.
+type Info struct {
+	UptimeSeconds float64 `json:"uptime_seconds"`
+	Goroutines    int     `json:"goroutines"`
+	DBPingOK      bool    `json:"db_ping_ok"`
+	GoVersion     string  `json:"go_version"`
+}
+
+// Pinger is implemented by stores that can check database connectivity.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+var startTime = time.Now()
+
+// Collect gathers Info, using pinger to check database connectivity if
+// it is non-nil.
+func Collect(ctx context.Context, pinger Pinger) Info {
+	info := Info{
+		UptimeSeconds: time.Since(startTime).Seconds(),
+		Goroutines:    runtime.NumGoroutine(),
+		GoVersion:     runtime.Version(),
+	}
+
+	if pinger != nil {
+		info.DBPingOK = pinger.Ping(ctx) == nil
+	}
+
+	return info
+}
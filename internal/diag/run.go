@@ -0,0 +1,71 @@
+package diag
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"time"
+)
+
+// maxOutputBytes caps how much stdout/stderr RunAllowed captures from a
+// command, bounding memory use regardless of what the command prints.
+const maxOutputBytes = 64 * 1024
+
+// allowedCommands is the hard-coded set of ops commands RunAllowed may
+// execute, each with its own timeout. There is deliberately no
+// shell-interpreted entry point: arguments go straight to
+// exec.CommandContext, never through "sh -c".
+var allowedCommands = map[string]time.Duration{
+	"uptime": 2 * time.Second,
+	"df":     2 * time.Second,
+	"vmstat": 2 * time.Second,
+}
+
+// RunAllowed runs name with args if name is in the vetted command
+// allow-list, returning its combined stdout/stderr truncated to
+// maxOutputBytes. It returns an error if name is not allow-listed, if
+// the command times out, or if the command itself fails.
+func RunAllowed(name string, args ...string) (string, error) {
+	timeout, ok := allowedCommands[name]
+	if !ok {
+		return "", fmt.Errorf("diag: command %q is not allowed", name)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	var out bytes.Buffer
+	limited := &limitedWriter{w: &out, limit: maxOutputBytes}
+	cmd.Stdout = limited
+	cmd.Stderr = limited
+
+	err := cmd.Run()
+	return out.String(), err
+}
+
+// limitedWriter discards writes past limit so a misbehaving command
+// cannot exhaust memory, while still reporting the full byte count to
+// its caller as io.Writer requires.
+type limitedWriter struct {
+	w     io.Writer
+	limit int
+	n     int
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if l.n < l.limit {
+		remaining := l.limit - l.n
+		if remaining > len(p) {
+			remaining = len(p)
+		}
+		n, err := l.w.Write(p[:remaining])
+		l.n += n
+		if err != nil {
+			return n, err
+		}
+	}
+	return len(p), nil
+}
@@ -0,0 +1,104 @@
+package patient
+
+import "sync"
+
+// MemStore is an in-memory Store backed by a map, suitable for tests and
+// for running the server without a database.
+type MemStore struct {
+	mu       sync.Mutex
+	patients map[int]Patient
+	counter  int
+}
+
+// NewMemStore returns an empty, ready to use MemStore.
+func NewMemStore() *MemStore {
+	return &MemStore{
+		patients: make(map[int]Patient),
+		counter:  1,
+	}
+}
+
+func (s *MemStore) List() ([]Patient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]Patient, 0, len(s.patients))
+	for _, p := range s.patients {
+		list = append(list, p)
+	}
+	return list, nil
+}
+
+func (s *MemStore) Find(id int) (Patient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.patients[id]
+	if !ok {
+		return Patient{}, ErrNotFound
+	}
+	return p, nil
+}
+
+func (s *MemStore) Add(p Patient) (Patient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.patients {
+		if existing.Name == p.Name {
+			return Patient{}, ErrDuplicateName
+		}
+	}
+
+	p.ID = s.counter
+	s.counter++
+	s.patients[p.ID] = p
+	return p, nil
+}
+
+func (s *MemStore) Update(id int, p Patient) (Patient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.patients[id]
+	if !ok {
+		return Patient{}, ErrNotFound
+	}
+
+	for otherID, other := range s.patients {
+		if otherID != id && other.Name == p.Name {
+			return Patient{}, ErrDuplicateName
+		}
+	}
+
+	existing.Name = p.Name
+	existing.Age = p.Age
+	if p.Password != "" {
+		existing.Password = p.Password
+	}
+	s.patients[id] = existing
+	return existing, nil
+}
+
+func (s *MemStore) Delete(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.patients[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.patients, id)
+	return nil
+}
+
+func (s *MemStore) FindByName(name string) (Patient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range s.patients {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return Patient{}, ErrNotFound
+}
@@ -0,0 +1,41 @@
+package patient
+
+import "testing"
+
+func TestMemStore_UpdateRejectsDuplicateName(t *testing.T) {
+	s := NewMemStore()
+
+	alice, err := s.Add(Patient{Name: "Alice Wonder", Age: 28})
+	if err != nil {
+		t.Fatalf("add alice: %v", err)
+	}
+	bob, err := s.Add(Patient{Name: "Bob Builder", Age: 40})
+	if err != nil {
+		t.Fatalf("add bob: %v", err)
+	}
+
+	if _, err := s.Update(bob.ID, Patient{Name: alice.Name, Age: bob.Age}); err != ErrDuplicateName {
+		t.Fatalf("got error %v, want ErrDuplicateName", err)
+	}
+
+	got, err := s.Find(bob.ID)
+	if err != nil {
+		t.Fatalf("find bob: %v", err)
+	}
+	if got.Name != "Bob Builder" {
+		t.Fatalf("bob's name was mutated despite the rejected update: %+v", got)
+	}
+}
+
+func TestMemStore_UpdateAllowsOwnName(t *testing.T) {
+	s := NewMemStore()
+
+	alice, err := s.Add(Patient{Name: "Alice Wonder", Age: 28})
+	if err != nil {
+		t.Fatalf("add alice: %v", err)
+	}
+
+	if _, err := s.Update(alice.ID, Patient{Name: "Alice Wonder", Age: 29}); err != nil {
+		t.Fatalf("update with unchanged name: %v", err)
+	}
+}
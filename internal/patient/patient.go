@@ -0,0 +1,25 @@
+// Package patient contains the Patient domain model and the Store
+// interface used to persist it.
+package patient
+
+import "errors"
+
+// Patient represents a patient registered in the hospital.
+type Patient struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Age      int    `json:"age"`
+	Password string `json:"password"`
+}
+
+// Sentinel errors returned by Store implementations so callers can
+// distinguish failure modes without inspecting error strings.
+var (
+	// ErrNotFound is returned when no patient matches the requested ID.
+	ErrNotFound = errors.New("patient: not found")
+	// ErrDuplicateName is returned when a patient with the same name
+	// already exists.
+	ErrDuplicateName = errors.New("patient: duplicate name")
+	// ErrInvalidName is returned when a patient name fails validation.
+	ErrInvalidName = errors.New("patient: invalid name")
+)
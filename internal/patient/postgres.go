@@ -0,0 +1,153 @@
+package patient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresStore is a Store backed by a PostgreSQL database, accessed
+// through a pgxpool connection pool.
+//
+// It expects a "patients" table with columns (id serial primary key,
+// name text unique, age int, password text).
+type PostgresStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresStore connects to the database identified by dsn and
+// returns a ready to use PostgresStore. Callers are responsible for
+// closing the returned store's pool via Close when done.
+func NewPostgresStore(ctx context.Context, dsn string) (*PostgresStore, error) {
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return &PostgresStore{pool: pool}, nil
+}
+
+// Close releases the underlying connection pool.
+func (s *PostgresStore) Close() {
+	s.pool.Close()
+}
+
+// Ping checks database connectivity, satisfying diag.Pinger.
+func (s *PostgresStore) Ping(ctx context.Context) error {
+	return s.pool.Ping(ctx)
+}
+
+func (s *PostgresStore) List() ([]Patient, error) {
+	ctx := context.Background()
+	rows, err := s.pool.Query(ctx, `SELECT id, name, age, password FROM patients ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []Patient
+	for rows.Next() {
+		var p Patient
+		if err := rows.Scan(&p.ID, &p.Name, &p.Age, &p.Password); err != nil {
+			return nil, err
+		}
+		list = append(list, p)
+	}
+	return list, rows.Err()
+}
+
+func (s *PostgresStore) Find(id int) (Patient, error) {
+	ctx := context.Background()
+	var p Patient
+	err := s.pool.QueryRow(ctx, `SELECT id, name, age, password FROM patients WHERE id = $1`, id).
+		Scan(&p.ID, &p.Name, &p.Age, &p.Password)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Patient{}, ErrNotFound
+	}
+	if err != nil {
+		return Patient{}, err
+	}
+	return p, nil
+}
+
+func (s *PostgresStore) Add(p Patient) (Patient, error) {
+	ctx := context.Background()
+	err := s.pool.QueryRow(ctx,
+		`INSERT INTO patients (name, age, password) VALUES ($1, $2, $3) RETURNING id`,
+		p.Name, p.Age, p.Password,
+	).Scan(&p.ID)
+	if isUniqueViolation(err) {
+		return Patient{}, ErrDuplicateName
+	}
+	if err != nil {
+		return Patient{}, err
+	}
+	return p, nil
+}
+
+func (s *PostgresStore) Update(id int, p Patient) (Patient, error) {
+	ctx := context.Background()
+
+	existing, err := s.Find(id)
+	if err != nil {
+		return Patient{}, err
+	}
+
+	existing.Name = p.Name
+	existing.Age = p.Age
+	if p.Password != "" {
+		existing.Password = p.Password
+	}
+
+	_, err = s.pool.Exec(ctx,
+		`UPDATE patients SET name = $1, age = $2, password = $3 WHERE id = $4`,
+		existing.Name, existing.Age, existing.Password, id,
+	)
+	if isUniqueViolation(err) {
+		return Patient{}, ErrDuplicateName
+	}
+	if err != nil {
+		return Patient{}, err
+	}
+	return existing, nil
+}
+
+func (s *PostgresStore) Delete(id int) error {
+	ctx := context.Background()
+	tag, err := s.pool.Exec(ctx, `DELETE FROM patients WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *PostgresStore) FindByName(name string) (Patient, error) {
+	ctx := context.Background()
+	var p Patient
+	err := s.pool.QueryRow(ctx,
+		`SELECT id, name, age, password FROM patients WHERE name = $1`,
+		name,
+	).Scan(&p.ID, &p.Name, &p.Age, &p.Password)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Patient{}, ErrNotFound
+	}
+	if err != nil {
+		return Patient{}, err
+	}
+	return p, nil
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique
+// constraint violation (SQLSTATE 23505).
+func isUniqueViolation(err error) bool {
+	var pgErr interface{ SQLState() string }
+	return errors.As(err, &pgErr) && pgErr.SQLState() == "23505"
+}
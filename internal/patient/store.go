@@ -0,0 +1,25 @@
+package patient
+
+// Store abstracts persistence for patients so callers can swap an
+// in-memory implementation for a real database without touching the
+// HTTP layer.
+type Store interface {
+	// List returns every patient currently stored.
+	List() ([]Patient, error)
+	// Find returns the patient with the given ID, or ErrNotFound.
+	Find(id int) (Patient, error)
+	// Add stores p, assigns it an ID and returns the stored copy.
+	// It returns ErrDuplicateName if a patient with the same name
+	// already exists.
+	Add(p Patient) (Patient, error)
+	// Update replaces the patient with the given ID using the fields
+	// set on p, leaving the password untouched if p.Password is empty.
+	// It returns ErrNotFound if no such patient exists.
+	Update(id int, p Patient) (Patient, error)
+	// Delete removes the patient with the given ID, or returns
+	// ErrNotFound if no such patient exists.
+	Delete(id int) error
+	// FindByName returns the patient with the given name, or
+	// ErrNotFound if no such patient exists.
+	FindByName(name string) (Patient, error)
+}
@@ -0,0 +1,73 @@
+// Package server assembles the patient API's HTTP handlers and
+// middleware into a single, independently instantiable Server, so tests
+// (and main) can spin up isolated instances backed by any patient.Store.
+package server
+
+import (
+	"net/http"
+
+	"github.com/andreasaldito/dummy-register/internal/api"
+	"github.com/andreasaldito/dummy-register/internal/auth"
+	"github.com/andreasaldito/dummy-register/internal/config"
+	"github.com/andreasaldito/dummy-register/internal/diag"
+	"github.com/andreasaldito/dummy-register/internal/patient"
+)
+
+// Server holds everything needed to serve the patient API.
+type Server struct {
+	store  patient.Store
+	tokens *auth.TokenStore
+	cfg    *config.Manager
+
+	adminUser, adminPass string
+}
+
+// NewServer returns a Server backed by store, with an empty token store
+// and default (zero-value) config. Use WithConfig and WithDiagnostics
+// to customize either before calling Routes.
+func NewServer(store patient.Store) *Server {
+	return &Server{
+		store:  store,
+		tokens: auth.NewTokenStore(),
+		cfg:    config.NewManager(config.Config{}),
+	}
+}
+
+// WithConfig replaces the Server's config manager, e.g. to share one
+// loaded from config.yaml and watched for changes.
+func (s *Server) WithConfig(cfg *config.Manager) *Server {
+	s.cfg = cfg
+	return s
+}
+
+// WithDiagnostics enables GET /api/v1/diagnostics, gated by the given
+// admin BasicAuth credentials.
+func (s *Server) WithDiagnostics(adminUser, adminPass string) *Server {
+	s.adminUser, s.adminPass = adminUser, adminPass
+	return s
+}
+
+// Routes builds the complete set of HTTP routes for the patient API.
+func (s *Server) Routes() http.Handler {
+	var patientHandler http.Handler = api.NewPatientHandler(s.store)
+	patientHandler = api.RequireBearerTokenForMutations(s.tokens, patientHandler)
+	patientHandler = api.CORS(s.cfg, patientHandler)
+	patientHandler = api.RateLimit(s.cfg, patientHandler)
+
+	mux := http.NewServeMux()
+	mux.Handle("/api/v1/patients", patientHandler)
+	mux.Handle("/api/v1/patients/", patientHandler)
+	mux.Handle("/api/v1/login", api.NewLoginHandler(s.store, s.tokens))
+
+	// Config (which can expose database_dsn) and diagnostics are both
+	// admin-only and both disabled, rather than left open, if no admin
+	// credentials are configured.
+	if s.adminUser != "" && s.adminPass != "" {
+		mux.Handle("/api/v1/config", api.RequireAdminBasicAuth(s.adminUser, s.adminPass, api.NewConfigHandler(s.cfg)))
+
+		pinger, _ := s.store.(diag.Pinger)
+		mux.Handle("/api/v1/diagnostics", api.NewDiagnosticsHandler(pinger, s.adminUser, s.adminPass))
+	}
+
+	return mux
+}
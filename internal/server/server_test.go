@@ -0,0 +1,441 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/andreasaldito/dummy-register/internal/auth"
+	"github.com/andreasaldito/dummy-register/internal/config"
+	"github.com/andreasaldito/dummy-register/internal/patient"
+)
+
+func newTestServer() http.Handler {
+	return NewServer(patient.NewMemStore()).Routes()
+}
+
+func doRequest(t *testing.T, h http.Handler, method, path, token, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func login(t *testing.T, h http.Handler, name, password string) string {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]string{"name": name, "password": password})
+	rec := doRequest(t, h, http.MethodPost, "/api/v1/login", "", string(body))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("login: got status %d, body %q", rec.Code, rec.Body.String())
+	}
+
+	var resp struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("login: decode response: %v", err)
+	}
+	return resp.Token
+}
+
+func createPatient(t *testing.T, h http.Handler, token, name, password string) int {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]interface{}{"name": name, "age": 30, "password": password})
+	rec := doRequest(t, h, http.MethodPost, "/api/v1/patients", token, string(body))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create patient: got status %d, body %q", rec.Code, rec.Body.String())
+	}
+
+	var created struct {
+		ID int `json:"id"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("create patient: decode response: %v", err)
+	}
+	return created.ID
+}
+
+func TestCreatePatient_HappyPath(t *testing.T) {
+	h := newTestServer()
+
+	body := `{"name":"John Smith","age":30,"password":"hunter2"}`
+	rec := doRequest(t, h, http.MethodPost, "/api/v1/patients", "", body)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("got status %d, body %q", rec.Code, rec.Body.String())
+	}
+	if strings.Contains(rec.Body.String(), "hunter2") || strings.Contains(rec.Body.String(), "password") {
+		t.Fatalf("response leaked the password: %q", rec.Body.String())
+	}
+}
+
+func TestCreatePatient_DuplicateName(t *testing.T) {
+	h := newTestServer()
+
+	body := `{"name":"John Smith","age":30,"password":"hunter2"}`
+	doRequest(t, h, http.MethodPost, "/api/v1/patients", "", body)
+	rec := doRequest(t, h, http.MethodPost, "/api/v1/patients", "", body)
+
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusConflict)
+	}
+}
+
+func TestCreatePatient_InvalidJSON(t *testing.T) {
+	h := newTestServer()
+
+	rec := doRequest(t, h, http.MethodPost, "/api/v1/patients", "", `{not json`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestMutatingRoutes_RequireBearerToken(t *testing.T) {
+	h := newTestServer()
+
+	// POST is excluded here: it's how a patient registers, so it can't
+	// require a token already issued to that same patient.
+	tests := []struct {
+		method, path string
+	}{
+		{http.MethodPut, "/api/v1/patients/1"},
+		{http.MethodDelete, "/api/v1/patients/1"},
+	}
+
+	for _, tt := range tests {
+		rec := doRequest(t, h, tt.method, tt.path, "", `{"name":"Jane Doe","age":1,"password":"x"}`)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s %s: got status %d, want %d", tt.method, tt.path, rec.Code, http.StatusUnauthorized)
+		}
+	}
+}
+
+func TestGetPatient_NeverReturnsPassword(t *testing.T) {
+	h := newTestServer()
+	token := bootstrapPatientAndLogin(t, h, "Jane Doe", "correct-horse")
+	id := lookUpOwnID(t, h, token)
+
+	rec := doRequest(t, h, http.MethodGet, "/api/v1/patients/"+strconv.Itoa(id), "", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d, body %q", rec.Code, rec.Body.String())
+	}
+	if bytes.Contains(rec.Body.Bytes(), []byte("correct-horse")) || bytes.Contains(rec.Body.Bytes(), []byte(`"password"`)) {
+		t.Fatalf("response leaked the password: %q", rec.Body.String())
+	}
+}
+
+func TestUpdatePatient_HappyPath(t *testing.T) {
+	h := newTestServer()
+	token := bootstrapPatientAndLogin(t, h, "Jane Doe", "correct-horse")
+	id := lookUpOwnID(t, h, token)
+
+	body := `{"name":"Jane Doe","age":31}`
+	rec := doRequest(t, h, http.MethodPut, "/api/v1/patients/"+strconv.Itoa(id), token, body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d, body %q", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"age":31`) {
+		t.Fatalf("update did not take effect: %q", rec.Body.String())
+	}
+}
+
+func TestDeletePatient_HappyPath(t *testing.T) {
+	h := newTestServer()
+	token := bootstrapPatientAndLogin(t, h, "Jane Doe", "correct-horse")
+	id := lookUpOwnID(t, h, token)
+
+	rec := doRequest(t, h, http.MethodDelete, "/api/v1/patients/"+strconv.Itoa(id), token, "")
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("got status %d, body %q", rec.Code, rec.Body.String())
+	}
+
+	rec = doRequest(t, h, http.MethodGet, "/api/v1/patients/"+strconv.Itoa(id), "", "")
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("got status %d after delete, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestUpdatePatient_DuplicateName(t *testing.T) {
+	h := newTestServer()
+	createPatient(t, h, "", "Alice Wonder", "alice-pw")
+	bobToken := bootstrapPatientAndLogin(t, h, "Bob Builder", "bob-pw")
+	bobID := findPatientIDByName(t, h, "Bob Builder")
+
+	body := `{"name":"Alice Wonder","age":40}`
+	rec := doRequest(t, h, http.MethodPut, "/api/v1/patients/"+strconv.Itoa(bobID), bobToken, body)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusConflict)
+	}
+}
+
+func TestConfigPatch_PasswordHashCost_AppliesToHashPassword(t *testing.T) {
+	t.Cleanup(func() { auth.SetCost(bcrypt.DefaultCost) })
+
+	store := patient.NewMemStore()
+	cfgManager := config.NewManager(config.Config{})
+	h := NewServer(store).WithConfig(cfgManager).WithDiagnostics("admin", "secretpw").Routes()
+
+	getReq := httptest.NewRequest(http.MethodGet, "/api/v1/config", nil)
+	getReq.SetBasicAuth("admin", "secretpw")
+	getRec := httptest.NewRecorder()
+	h.ServeHTTP(getRec, getReq)
+	if getRec.Code != http.StatusOK {
+		t.Fatalf("GET config: got status %d, body %q", getRec.Code, getRec.Body.String())
+	}
+	etag := getRec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("GET config: response carried no ETag")
+	}
+
+	const newCost = bcrypt.MinCost
+	patchBody, _ := json.Marshal(map[string]interface{}{"path": "/password_hash_cost", "value": newCost})
+	patchReq := httptest.NewRequest(http.MethodPatch, "/api/v1/config", bytes.NewReader(patchBody))
+	patchReq.SetBasicAuth("admin", "secretpw")
+	patchReq.Header.Set("If-Match", etag)
+	patchReq.Header.Set("Content-Type", "application/json")
+	patchRec := httptest.NewRecorder()
+	h.ServeHTTP(patchRec, patchReq)
+	if patchRec.Code != http.StatusOK {
+		t.Fatalf("PATCH config: got status %d, body %q", patchRec.Code, patchRec.Body.String())
+	}
+
+	id := createPatient(t, h, "", "Cost Check", "hunter2")
+	created, err := store.Find(id)
+	if err != nil {
+		t.Fatalf("find created patient: %v", err)
+	}
+
+	gotCost, err := bcrypt.Cost([]byte(created.Password))
+	if err != nil {
+		t.Fatalf("decode bcrypt cost: %v", err)
+	}
+	if gotCost != newCost {
+		t.Fatalf("password hashed with cost %d, want %d - PATCH /api/v1/config did not reach auth.SetCost", gotCost, newCost)
+	}
+}
+
+func TestConfigEndpoint_RequiresAdminAuth(t *testing.T) {
+	h := NewServer(patient.NewMemStore()).WithDiagnostics("admin", "secretpw").Routes()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/config", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestConfigPatch(t *testing.T) {
+	newConfigServer := func() (http.Handler, string) {
+		h := NewServer(patient.NewMemStore()).WithDiagnostics("admin", "secretpw").Routes()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/config", nil)
+		req.SetBasicAuth("admin", "secretpw")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return h, rec.Header().Get("ETag")
+	}
+
+	tests := []struct {
+		name       string
+		ifMatch    func(etag string) string
+		path       string
+		value      interface{}
+		wantStatus int
+	}{
+		{
+			name:       "happy path",
+			ifMatch:    func(etag string) string { return etag },
+			path:       "/rate_limit_rps",
+			value:      7,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing If-Match",
+			ifMatch:    func(string) string { return "" },
+			path:       "/rate_limit_rps",
+			value:      7,
+			wantStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name:       "stale If-Match",
+			ifMatch:    func(string) string { return "not-the-real-etag" },
+			path:       "/rate_limit_rps",
+			value:      7,
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:       "unknown field",
+			ifMatch:    func(etag string) string { return etag },
+			path:       "/nonexistent_field",
+			value:      7,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, etag := newConfigServer()
+
+			body, _ := json.Marshal(map[string]interface{}{"path": tt.path, "value": tt.value})
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/config", bytes.NewReader(body))
+			req.SetBasicAuth("admin", "secretpw")
+			req.Header.Set("Content-Type", "application/json")
+			if im := tt.ifMatch(etag); im != "" {
+				req.Header.Set("If-Match", im)
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d, body %q", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestDiagnostics_RunsAllowedCommand(t *testing.T) {
+	h := NewServer(patient.NewMemStore()).WithDiagnostics("admin", "secretpw").Routes()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/diagnostics?cmd=uptime", nil)
+	req.SetBasicAuth("admin", "secretpw")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d, body %q", rec.Code, rec.Body.String())
+	}
+
+	var resp struct {
+		Command string `json:"command"`
+		Output  string `json:"output"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Command != "uptime" {
+		t.Fatalf("got command %q, want %q", resp.Command, "uptime")
+	}
+	if resp.Output == "" {
+		t.Fatal("expected non-empty command output")
+	}
+}
+
+func TestDiagnostics_RejectsDisallowedCommand(t *testing.T) {
+	h := NewServer(patient.NewMemStore()).WithDiagnostics("admin", "secretpw").Routes()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/diagnostics?cmd=rm", nil)
+	req.SetBasicAuth("admin", "secretpw")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestDeletePatient_RejectsOtherPatientsToken(t *testing.T) {
+	h := newTestServer()
+	aliceToken := bootstrapPatientAndLogin(t, h, "Alice Wonder", "alice-pw")
+	bootstrapPatientAndLogin(t, h, "Bob Builder", "bob-pw")
+	bobID := findPatientIDByName(t, h, "Bob Builder")
+
+	rec := doRequest(t, h, http.MethodDelete, "/api/v1/patients/"+strconv.Itoa(bobID), aliceToken, "")
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("delete with Alice's token against Bob's record: got status %d, want %d", rec.Code, http.StatusForbidden)
+	}
+
+	rec = doRequest(t, h, http.MethodGet, "/api/v1/patients/"+strconv.Itoa(bobID), "", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Bob's record should still exist after the rejected delete: got status %d", rec.Code)
+	}
+}
+
+func TestLogin_UpgradesLegacyMD5Hash(t *testing.T) {
+	store := patient.NewMemStore()
+	legacyMD5 := "90742342547f8e0e4f87f8e3c49b8e0e" // arbitrary-looking hex; mismatches on purpose below
+	created, err := store.Add(patient.Patient{Name: "Legacy Pat", Age: 40, Password: legacyMD5})
+	if err != nil {
+		t.Fatalf("seed store: %v", err)
+	}
+
+	h := NewServer(store).Routes()
+	body, _ := json.Marshal(map[string]string{"name": created.Name, "password": "does-not-match"})
+	rec := doRequest(t, h, http.MethodPost, "/api/v1/login", "", string(body))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("got status %d, want %d for mismatched legacy hash", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+// bootstrapPatientAndLogin creates a patient with the given name and
+// password and returns a bearer token authenticated as that patient.
+func bootstrapPatientAndLogin(t *testing.T, h http.Handler, name, password string) string {
+	t.Helper()
+	createPatient(t, h, "", name, password)
+	return login(t, h, name, password)
+}
+
+// lookUpOwnID lists patients and returns the ID of the only entry,
+// since the handlers never echo back plain IDs in the login response.
+func lookUpOwnID(t *testing.T, h http.Handler, token string) int {
+	t.Helper()
+
+	rec := doRequest(t, h, http.MethodGet, "/api/v1/patients", token, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list patients: got status %d, body %q", rec.Code, rec.Body.String())
+	}
+
+	var list []struct {
+		ID int `json:"id"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("list patients: decode response: %v", err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("expected exactly one patient, got %d", len(list))
+	}
+	return list[0].ID
+}
+
+// findPatientIDByName lists patients and returns the ID of the one
+// named name, for tests juggling more than one patient at a time.
+func findPatientIDByName(t *testing.T, h http.Handler, name string) int {
+	t.Helper()
+
+	rec := doRequest(t, h, http.MethodGet, "/api/v1/patients", "", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list patients: got status %d, body %q", rec.Code, rec.Body.String())
+	}
+
+	var list []struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("list patients: decode response: %v", err)
+	}
+	for _, p := range list {
+		if p.Name == name {
+			return p.ID
+		}
+	}
+	t.Fatalf("did not find patient named %q in %+v", name, list)
+	return 0
+}